@@ -23,20 +23,26 @@ a service.
 package main
 
 import (
+	"context"
 	"flag"
 	"fmt"
-	"io/ioutil"
 	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/apimachinery/pkg/util/intstr"
 	clientset "k8s.io/client-go/kubernetes"
 	restclient "k8s.io/client-go/rest"
 	"k8s.io/klog"
-	"k8s.io/kubernetes/test/e2e/framework"
+	"k8s.io/kubernetes/test/soak/pkg/soak"
+	"k8s.io/kubernetes/test/soak/pkg/soak/chaos"
+	"k8s.io/kubernetes/test/soak/pkg/soak/driver"
+	"k8s.io/kubernetes/test/soak/pkg/soak/kubeclient"
+	"k8s.io/kubernetes/test/soak/pkg/soak/protocoltarget"
 )
 
 var (
@@ -44,8 +50,37 @@ var (
 	podsPerNode    = flag.Int("pods_per_node", 1, "Number of serve_hostname pods per node")
 	upTo           = flag.Int("up_to", 1, "Number of iterations or -1 for no limit")
 	maxPar         = flag.Int("max_in_flight", 100, "Maximum number of queries in flight")
+
+	loadGeneratorName = flag.String("load_generator", "get", "Load generator to use: get, keepalive, http2 or tcp")
+	histogramOut      = flag.String("histogram_out", "", "If set, write a per-iteration latency summary (CSV, or JSON if the path ends in .json) to this path")
+	warmupDuration    = flag.Duration("warmup_duration", 0, "How long to run the load generator before recording latencies, to let connection pools and caches settle")
+	thinkTime         = flag.Duration("think_time", 0, "Delay each worker waits between requests")
+
+	workload  = flag.String("workload", "closed", "Workload mode: \"closed\" floods queries bounded only by max_in_flight, \"open\" schedules a Poisson arrival process at target_qps so queueing delay isn't hidden (coordinated omission)")
+	targetQPS = flag.Float64("target_qps", 10, "Target arrival rate for --workload=open")
+
+	targetName = flag.String("target", "http", "Service target to soak test: http, grpc, dns or udp")
+
+	chaosInterval = flag.Duration("chaos_interval", 0, "If non-zero, inject one chaos action (see --chaos_actions) at this interval while the query loop runs")
+	chaosActions  = flag.String("chaos_actions", "pod-delete,node-cordon", "Comma-separated chaos actions to choose from: pod-delete, node-cordon, rolling-update. rolling-update is opt-in only: this soak test creates its pods directly rather than via a ReplicationController, so the action has no target unless a caller wires one up with Injector.SetRollingUpdateTarget")
+	chaosBudget   = flag.Int("chaos_budget", 1, "Maximum number of chaos disruptions running concurrently")
 )
 
+// recoveryWindow is the bucket size RecoveryTracker uses to compute success
+// rate, and so the granularity of the recovery times reported per
+// disruption.
+const recoveryWindow = 10 * time.Second
+
+// recoverySearchLimit bounds how long after a disruption we look for the
+// success rate to return to baseline before giving up on that one.
+const recoverySearchLimit = 5 * time.Minute
+
+// recoveryRetention bounds how long RecoveryTracker keeps outcomes around:
+// enough to cover a baseline lookback of one recoveryWindow before a
+// disruption plus a recoverySearchLimit-long search after it, with room to
+// spare, so an --up_to -1 run's memory use doesn't grow without bound.
+const recoveryRetention = recoveryWindow + 2*recoverySearchLimit
+
 const (
 	deleteTimeout          = 2 * time.Minute
 	endpointTimeout        = 5 * time.Minute
@@ -62,6 +97,12 @@ func main() {
 	klog.Infof("Starting cauldron soak test with queries=%d podsPerNode=%d upTo=%d maxPar=%d",
 		*queriesAverage, *podsPerNode, *upTo, *maxPar)
 
+	soakTarget, err := protocoltarget.New(*targetName)
+	if err != nil {
+		klog.Fatalf("Invalid --target: %v", err)
+	}
+	klog.Infof("Using %q target", soakTarget.Name())
+
 	cc, err := restclient.InClusterConfig()
 	if err != nil {
 		klog.Fatalf("Failed to make client: %v", err)
@@ -73,13 +114,10 @@ func main() {
 	}
 
 	var nodes *corev1.NodeList
-	for start := time.Now(); time.Since(start) < nodeListTimeout; time.Sleep(2 * time.Second) {
+	err = kubeclient.WithRetries(context.Background(), kubeclient.DefaultRetryPolicy(nodeListTimeout), func() error {
 		nodes, err = client.CoreV1().Nodes().List(metav1.ListOptions{})
-		if err == nil {
-			break
-		}
-		klog.Warningf("Failed to list nodes: %v", err)
-	}
+		return err
+	})
 	if err != nil {
 		klog.Fatalf("Giving up trying to list nodes: %v", err)
 	}
@@ -104,16 +142,16 @@ func main() {
 	defer func(ns string) {
 		if err := client.CoreV1().Namespaces().Delete(ns, nil); err != nil {
 			klog.Warningf("Failed to delete namespace %s: %v", ns, err)
-		} else {
-			// wait until the namespace disappears
-			for i := 0; i < int(namespaceDeleteTimeout/time.Second); i++ {
-				if _, err := client.CoreV1().Namespaces().Get(ns, metav1.GetOptions{}); err != nil {
-					if errors.IsNotFound(err) {
-						return
-					}
-				}
-				time.Sleep(time.Second)
-			}
+			return
+		}
+		// wait until the namespace disappears
+		policy := kubeclient.FixedIntervalRetryPolicy(time.Second, namespaceDeleteTimeout)
+		err := kubeclient.PollWithRetries(context.Background(), policy, func() (bool, error) {
+			_, err := client.CoreV1().Namespaces().Get(ns, metav1.GetOptions{})
+			return errors.IsNotFound(err), nil
+		})
+		if err != nil {
+			klog.Warningf("Namespace %s did not disappear within %v", ns, namespaceDeleteTimeout)
 		}
 	}(ns)
 	klog.Infof("Created namespace %s", ns)
@@ -122,7 +160,7 @@ func main() {
 	klog.Infof("Creating service %s/serve-hostnames", ns)
 	// Make several attempts to create a service.
 	var svc *corev1.Service
-	for start := time.Now(); time.Since(start) < serviceCreateTimeout; time.Sleep(2 * time.Second) {
+	err = kubeclient.WithRetries(context.Background(), kubeclient.DefaultRetryPolicy(serviceCreateTimeout), func() error {
 		t := time.Now()
 		svc, err = client.CoreV1().Services(ns).Create(&corev1.Service{
 			ObjectMeta: metav1.ObjectMeta{
@@ -132,49 +170,41 @@ func main() {
 				},
 			},
 			Spec: corev1.ServiceSpec{
-				Ports: []corev1.ServicePort{{
-					Protocol:   "TCP",
-					Port:       9376,
-					TargetPort: intstr.FromInt(9376),
-				}},
+				Ports: []corev1.ServicePort{soakTarget.ServicePort()},
 				Selector: map[string]string{
 					"name": "serve-hostname",
 				},
 			},
 		})
 		klog.V(4).Infof("Service create %s/server-hostnames took %v", ns, time.Since(t))
-		if err == nil {
-			break
-		}
-		klog.Warningf("After %v failed to create service %s/serve-hostnames: %v", time.Since(start), ns, err)
-	}
+		return err
+	})
 	if err != nil {
-		klog.Warningf("Unable to create service %s/%s: %v", ns, svc.Name, err)
+		klog.Warningf("Unable to create service %s/serve-hostnames: %v", ns, err)
 		return
 	}
 	// Clean up service
 	defer func() {
 		klog.Infof("Cleaning up service %s/serve-hostnames", ns)
-		// Make several attempts to delete the service.
-		for start := time.Now(); time.Since(start) < deleteTimeout; time.Sleep(1 * time.Second) {
-			if err := client.CoreV1().Services(ns).Delete(svc.Name, nil); err == nil {
-				return
-			}
-			klog.Warningf("After %v unable to delete service %s/%s: %v", time.Since(start), ns, svc.Name, err)
+		if err := kubeclient.WithRetries(context.Background(), kubeclient.DefaultRetryPolicy(deleteTimeout), func() error {
+			return client.CoreV1().Services(ns).Delete(svc.Name, nil)
+		}); err != nil {
+			klog.Warningf("Unable to delete service %s/%s: %v", ns, svc.Name, err)
 		}
 	}()
 
 	// Put serve-hostname pods on each node.
 	podNames := []string{}
+	podNode := map[string]string{}
 	for i, node := range nodes.Items {
 		for j := 0; j < *podsPerNode; j++ {
 			podName := fmt.Sprintf("serve-hostname-%d-%d", i, j)
 			podNames = append(podNames, podName)
-			// Make several attempts
-			for start := time.Now(); time.Since(start) < podCreateTimeout; time.Sleep(2 * time.Second) {
-				klog.Infof("Creating pod %s/%s on node %s", ns, podName, node.Name)
+			podNode[podName] = node.Name
+			klog.Infof("Creating pod %s/%s on node %s", ns, podName, node.Name)
+			err = kubeclient.WithRetries(context.Background(), kubeclient.DefaultRetryPolicy(podCreateTimeout), func() error {
 				t := time.Now()
-				_, err = client.CoreV1().Pods(ns).Create(&corev1.Pod{
+				_, err := client.CoreV1().Pods(ns).Create(&corev1.Pod{
 					ObjectMeta: metav1.ObjectMeta{
 						Name: podName,
 						Labels: map[string]string{
@@ -182,130 +212,310 @@ func main() {
 						},
 					},
 					Spec: corev1.PodSpec{
-						Containers: []corev1.Container{
-							{
-								Name:  "serve-hostname",
-								Image: framework.ServeHostnameImage,
-								Ports: []corev1.ContainerPort{{ContainerPort: 9376}},
-							},
-						},
-						NodeName: node.Name,
+						Containers: []corev1.Container{soakTarget.Container()},
+						NodeName:   node.Name,
 					},
 				})
 				klog.V(4).Infof("Pod create %s/%s request took %v", ns, podName, time.Since(t))
-				if err == nil {
-					break
-				}
-				klog.Warningf("After %s failed to create pod %s/%s: %v", time.Since(start), ns, podName, err)
-			}
+				return err
+			})
 			if err != nil {
 				klog.Warningf("Failed to create pod %s/%s: %v", ns, podName, err)
 				return
 			}
 		}
 	}
-	// Clean up the pods
+	// Clean up the pods. A pod chaos already deleted (pod-delete is on by
+	// default, see chaosActions) is gone by the time we get here, so treat
+	// NotFound as success rather than letting it fall through to the
+	// failure log below.
 	defer func() {
 		klog.Info("Cleaning up pods")
-		// Make several attempts to delete the pods.
 		for _, podName := range podNames {
-			for start := time.Now(); time.Since(start) < deleteTimeout; time.Sleep(1 * time.Second) {
-				if err = client.CoreV1().Pods(ns).Delete(podName, nil); err == nil {
-					break
+			podName := podName
+			if err := kubeclient.WithRetries(context.Background(), kubeclient.DefaultRetryPolicy(deleteTimeout), func() error {
+				err := client.CoreV1().Pods(ns).Delete(podName, nil)
+				if errors.IsNotFound(err) {
+					return nil
 				}
-				klog.Warningf("After %v failed to delete pod %s/%s: %v", time.Since(start), ns, podName, err)
+				return err
+			}); err != nil {
+				klog.Warningf("Failed to delete pod %s/%s: %v", ns, podName, err)
 			}
 		}
 	}()
 
 	klog.Info("Waiting for the serve-hostname pods to be ready")
+	podRunningPolicy := kubeclient.FixedIntervalRetryPolicy(5*time.Second, podStartTimeout)
 	for _, podName := range podNames {
+		podName := podName
 		var pod *corev1.Pod
-		for start := time.Now(); time.Since(start) < podStartTimeout; time.Sleep(5 * time.Second) {
-			pod, err = client.CoreV1().Pods(ns).Get(podName, metav1.GetOptions{})
-			if err != nil {
-				klog.Warningf("Get pod %s/%s failed, ignoring for %v: %v", ns, podName, err, podStartTimeout)
-				continue
-			}
-			if pod.Status.Phase == corev1.PodRunning {
-				break
+		err := kubeclient.PollWithRetries(context.Background(), podRunningPolicy, func() (bool, error) {
+			var getErr error
+			pod, getErr = client.CoreV1().Pods(ns).Get(podName, metav1.GetOptions{})
+			if getErr != nil {
+				klog.Warningf("Get pod %s/%s failed, ignoring for %v: %v", ns, podName, podStartTimeout, getErr)
+				return false, nil
 			}
-		}
-		if pod.Status.Phase != corev1.PodRunning {
+			return pod.Status.Phase == corev1.PodRunning, nil
+		})
+		if err != nil {
 			klog.Warningf("Gave up waiting on pod %s/%s to be running (saw %v)", ns, podName, pod.Status.Phase)
 		} else {
 			klog.Infof("%s/%s is running", ns, podName)
 		}
 	}
 
-	// Wait for the endpoints to propagate.
-	for start := time.Now(); time.Since(start) < endpointTimeout; time.Sleep(10 * time.Second) {
-		_, err = http.Get(fmt.Sprintf("http://serve-hostnames.%s:9376", ns))
-		if err == nil {
-			break
+	// Wait for the endpoints to propagate. Only the HTTP target can use a
+	// bare http.Get for this; the others rely on the warmup period (and
+	// individual Fire failures during it) to ride out a not-yet-ready
+	// Service.
+	if soakTarget.Name() == "http" {
+		endpointPolicy := kubeclient.FixedIntervalRetryPolicy(10*time.Second, endpointTimeout)
+		err = kubeclient.PollWithRetries(context.Background(), endpointPolicy, func() (bool, error) {
+			_, getErr := http.Get(fmt.Sprintf("http://serve-hostnames.%s:9376", ns))
+			if getErr != nil {
+				klog.Infof("Waiting for endpoints to propagate, got error %v", getErr)
+				return false, nil
+			}
+			return true, nil
+		})
+		if err != nil {
+			klog.Errorf("Failed to get a response from service: %v", err)
 		}
-		klog.Infof("After %v while making a request got error %v", time.Since(start), err)
 	}
+
+	loadGenerator, err := soakTarget.NewProbe(fmt.Sprintf("serve-hostnames.%s", ns), ns, *loadGeneratorName)
 	if err != nil {
-		klog.Errorf("Failed to get a response from service: %v", err)
+		klog.Fatalf("Failed to create probe for target %q: %v", soakTarget.Name(), err)
+	}
+	klog.Infof("Using %q load generator", loadGenerator.Name())
+
+	if *warmupDuration > 0 {
+		klog.Infof("Warming up for %v", *warmupDuration)
+		runQueries(loadGenerator, *maxPar, *warmupDuration, nil)
+	}
+
+	recoveryTracker := chaos.NewRecoveryTracker(recoveryWindow, recoveryRetention)
+	var disruptions []chaos.Disruption
+	if *chaosInterval > 0 {
+		actions, err := chaos.ParseActions(strings.Split(*chaosActions, ","))
+		if err != nil {
+			klog.Fatalf("Invalid --chaos_actions: %v", err)
+		}
+		injector := chaos.NewInjector(client, ns, actions, *chaosBudget,
+			func() []string { return podNames },
+			func() []string {
+				names := make([]string, 0, len(nodes.Items))
+				for _, node := range nodes.Items {
+					names = append(names, node.Name)
+				}
+				return names
+			})
+		chaosCtx, stopChaos := context.WithCancel(context.Background())
+		defer stopChaos()
+		disruptionCh := injector.Run(chaosCtx, *chaosInterval)
+		chaosDone := make(chan struct{})
+		go func() {
+			defer close(chaosDone)
+			for d := range disruptionCh {
+				klog.Warningf("Chaos: injected %s on %s at %v", d.Action, d.Target, d.Timestamp)
+				disruptions = append(disruptions, d)
+			}
+		}()
+		defer func() {
+			stopChaos()
+			<-chaosDone
+			reportRecoveryTimes(recoveryTracker, disruptions)
+		}()
 	}
 
 	// Repeatedly make requests.
 	for iteration := 0; iteration != *upTo; iteration++ {
-		responseChan := make(chan string, queries)
-		// Use a channel of size *maxPar to throttle the number
-		// of in-flight requests to avoid overloading the service.
-		inFlight := make(chan struct{}, *maxPar)
+		var report *soak.Report
+		var missing int
+		var recorder *driver.LatencyRecorder
 		start := time.Now()
-		for q := 0; q < queries; q++ {
-			go func(i int, query int) {
-				inFlight <- struct{}{}
-				t := time.Now()
-				resp, err := http.Get(fmt.Sprintf("http://serve-hostnames.%s:9376", ns))
-				klog.V(4).Infof("Call to serve-hostnames in namespace %s took %v", ns, time.Since(t))
-				if err != nil {
-					klog.Warningf("Call failed during iteration %d query %d : %v", i, query, err)
-					// If the query failed return a string which starts with a character
-					// that can't be part of a hostname.
-					responseChan <- fmt.Sprintf("!failed in iteration %d to issue query %d: %v", i, query, err)
-				} else {
-					defer resp.Body.Close()
-					hostname, err := ioutil.ReadAll(resp.Body)
-					if err != nil {
-						responseChan <- fmt.Sprintf("!failed in iteration %d to read body of response: %v", i, err)
-					} else {
-						responseChan <- string(hostname)
+
+		attributesToPod := soakTarget.AttributesToPod()
+		switch *workload {
+		case "open":
+			report, recorder, missing = runOpenLoopIteration(loadGenerator, queries, podNode, attributesToPod, recoveryTracker)
+		case "closed", "":
+			report, missing = runClosedLoopIteration(loadGenerator, queries, podNode, attributesToPod, recoveryTracker)
+		default:
+			klog.Fatalf("Unknown --workload %q, want \"open\" or \"closed\"", *workload)
+		}
+
+		// Report any pods that did not respond at all. Only meaningful for
+		// targets whose responses actually identify the serving pod.
+		if attributesToPod {
+			for n, node := range nodes.Items {
+				for i := 0; i < *podsPerNode; i++ {
+					name := fmt.Sprintf("serve-hostname-%d-%d", n, i)
+					if report.PodCount(name) == 0 {
+						klog.Warningf("No response from pod %s on node %s at iteration %d", name, node.Name, iteration)
 					}
 				}
-				<-inFlight
-			}(iteration, q)
-		}
-		responses := make(map[string]int, *podsPerNode*len(nodes.Items))
-		missing := 0
-		for q := 0; q < queries; q++ {
-			r := <-responseChan
-			klog.V(4).Infof("Got response from %s", r)
-			responses[r]++
-			// If the returned hostname starts with '!' then it indicates
-			// an error response.
-			if len(r) > 0 && r[0] == '!' {
-				klog.V(3).Infof("Got response %s", r)
-				missing++
 			}
 		}
 		if missing > 0 {
 			klog.Warningf("Missing %d responses out of %d", missing, queries)
 		}
-		// Report any nodes that did not respond.
-		for n, node := range nodes.Items {
-			for i := 0; i < *podsPerNode; i++ {
-				name := fmt.Sprintf("serve-hostname-%d-%d", n, i)
-				if _, ok := responses[name]; !ok {
-					klog.Warningf("No response from pod %s on node %s at iteration %d", name, node.Name, iteration)
-				}
+		if *histogramOut != "" {
+			if err := report.WriteSummary(*histogramOut); err != nil {
+				klog.Warningf("Failed to write histogram summary to %s: %v", *histogramOut, err)
+			}
+		}
+		overall := report.Overall()
+		klog.Infof("Iteration %d took %v for %d queries (%.2f QPS) with %d missing: p50=%v p90=%v p99=%v p999=%v",
+			iteration, time.Since(start), queries-missing, float64(queries-missing)/time.Since(start).Seconds(), missing,
+			overall.Percentile(50), overall.Percentile(90), overall.Percentile(99), overall.Percentile(99.9))
+		if recorder != nil {
+			svc, resp := recorder.ServiceTime(), recorder.ResponseTime()
+			klog.Infof("Iteration %d service time: p50=%v p90=%v p99=%v; response time (incl. queueing): p50=%v p90=%v p99=%v",
+				iteration, svc.Percentile(50), svc.Percentile(90), svc.Percentile(99),
+				resp.Percentile(50), resp.Percentile(90), resp.Percentile(99))
+		}
+	}
+}
+
+// runClosedLoopIteration floods the load generator with queries goroutines
+// bounded only by maxPar, the classic pattern that conflates queue time with
+// server latency. attributesToPod selects whether responses are attributed
+// to the pod/node that served them (only true for targets whose Response
+// identifies the pod, e.g. http) or folded into the overall histogram only.
+// It returns the resulting report and the number of failed requests.
+func runClosedLoopIteration(generator soak.LoadGenerator, queries int, podNode map[string]string, attributesToPod bool, recoveryTracker *chaos.RecoveryTracker) (*soak.Report, int) {
+	report := soak.NewReport()
+	missing := runQueriesN(generator, *maxPar, queries, func(resp soak.Response, err error, latency time.Duration) {
+		recoveryTracker.Record(time.Now(), err == nil)
+		if err != nil {
+			klog.V(3).Infof("Call failed: %v", err)
+			return
+		}
+		if attributesToPod {
+			report.Record(resp.Hostname, podNode[resp.Hostname], latency)
+		} else {
+			report.RecordOverall(latency)
+		}
+	})
+	return report, missing
+}
+
+// runOpenLoopIteration schedules queries requests as a Poisson arrival
+// process at *targetQPS via a driver.Scheduler, so a request that starts
+// late still has its queueing delay counted. attributesToPod selects whether
+// responses are attributed to the pod/node that served them (only true for
+// targets whose Response identifies the pod, e.g. http) or folded into the
+// overall histogram only. It returns the report (keyed on response time, the
+// number a caller actually experiences), the service-time/response-time
+// recorder, and the number of failed requests.
+func runOpenLoopIteration(generator soak.LoadGenerator, queries int, podNode map[string]string, attributesToPod bool, recoveryTracker *chaos.RecoveryTracker) (*soak.Report, *driver.LatencyRecorder, int) {
+	report := soak.NewReport()
+	recorder := driver.NewLatencyRecorder()
+	scheduler := driver.NewScheduler(*targetQPS, *maxPar)
+	var missing int32
+
+	scheduler.Run(context.Background(), queries, func(ctx context.Context, intendedStart, actualStart time.Time) {
+		if *thinkTime > 0 {
+			defer time.Sleep(*thinkTime)
+		}
+		resp, err := generator.Fire(ctx)
+		done := time.Now()
+		recorder.Record(intendedStart, actualStart, done)
+		recoveryTracker.Record(done, err == nil)
+		if err != nil {
+			atomic.AddInt32(&missing, 1)
+			klog.V(3).Infof("Call failed: %v", err)
+			return
+		}
+		if attributesToPod {
+			report.Record(resp.Hostname, podNode[resp.Hostname], done.Sub(intendedStart))
+		} else {
+			report.RecordOverall(done.Sub(intendedStart))
+		}
+	})
+	return report, recorder, int(missing)
+}
+
+// runQueries fires requests against generator using a pool of maxInFlight
+// workers for the given duration, invoking onResult (if non-nil) for each
+// one. It is used for the warmup phase; recorded closed-loop iterations use
+// runQueriesN instead.
+func runQueries(generator soak.LoadGenerator, maxInFlight int, duration time.Duration, onResult func(soak.Response, error, time.Duration)) {
+	inFlight := make(chan struct{}, maxInFlight)
+	var wg sync.WaitGroup
+	deadline := time.Now().Add(duration)
+	for time.Now().Before(deadline) {
+		inFlight <- struct{}{}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-inFlight }()
+			if *thinkTime > 0 {
+				time.Sleep(*thinkTime)
+			}
+			t := time.Now()
+			resp, err := generator.Fire(context.Background())
+			latency := time.Since(t)
+			if onResult != nil {
+				onResult(resp, err, latency)
 			}
+		}()
+	}
+	wg.Wait()
+}
+
+// runQueriesN fires exactly n requests against generator using a pool of
+// maxInFlight workers, invoking onResult for each one, and returns the
+// number of requests that failed.
+func runQueriesN(generator soak.LoadGenerator, maxInFlight int, n int, onResult func(soak.Response, error, time.Duration)) int {
+	inFlight := make(chan struct{}, maxInFlight)
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	missing := 0
+	for q := 0; q < n; q++ {
+		inFlight <- struct{}{}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-inFlight }()
+			if *thinkTime > 0 {
+				time.Sleep(*thinkTime)
+			}
+			t := time.Now()
+			resp, err := generator.Fire(context.Background())
+			latency := time.Since(t)
+			if err != nil {
+				mu.Lock()
+				missing++
+				mu.Unlock()
+			}
+			onResult(resp, err, latency)
+		}()
+	}
+	wg.Wait()
+	return missing
+}
+
+// reportRecoveryTimes logs, for each disruption chaos injected, how long the
+// success rate took to climb back to its pre-disruption baseline - the
+// availability SLO number an operator actually cares about, as opposed to
+// the run's aggregate QPS and error count.
+func reportRecoveryTimes(recoveryTracker *chaos.RecoveryTracker, disruptions []chaos.Disruption) {
+	for _, d := range disruptions {
+		baseline, ok := recoveryTracker.SuccessRate(d.Timestamp.Add(-recoveryWindow))
+		if !ok {
+			baseline = 1.0
+		}
+		recovery, found := recoveryTracker.RecoveryTime(d, baseline, 0.95, recoverySearchLimit)
+		if !found {
+			klog.Warningf("Disruption %s on %s at %v: success rate had not returned to baseline (%.1f%%) within %v",
+				d.Action, d.Target, d.Timestamp, baseline*100, recoverySearchLimit)
+			continue
 		}
-		klog.Infof("Iteration %d took %v for %d queries (%.2f QPS) with %d missing",
-			iteration, time.Since(start), queries-missing, float64(queries-missing)/time.Since(start).Seconds(), missing)
+		klog.Infof("Disruption %s on %s at %v: recovered to baseline (%.1f%%) in %v",
+			d.Action, d.Target, d.Timestamp, baseline*100, recovery)
 	}
 }
@@ -0,0 +1,170 @@
+/*
+Copyright 2015 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package chaos injects failures into a running cauldron soak test -
+// deleting pods, cordoning and draining nodes, rolling out a new image -
+// so the test measures recovery behavior under churn instead of only
+// steady-state latency.
+package chaos
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"sync"
+	"time"
+
+	clientset "k8s.io/client-go/kubernetes"
+	"k8s.io/klog"
+)
+
+// Action identifies one kind of disruption an Injector can perform.
+type Action string
+
+const (
+	ActionPodDelete     Action = "pod-delete"
+	ActionNodeCordon    Action = "node-cordon"
+	ActionRollingUpdate Action = "rolling-update"
+)
+
+// ParseActions converts the names of a --chaos_actions flag value, already
+// split on commas by the caller, into Actions, validating each one.
+func ParseActions(names []string) ([]Action, error) {
+	actions := make([]Action, 0, len(names))
+	for _, name := range names {
+		switch Action(name) {
+		case ActionPodDelete, ActionNodeCordon, ActionRollingUpdate:
+			actions = append(actions, Action(name))
+		default:
+			return nil, fmt.Errorf("unknown chaos action %q", name)
+		}
+	}
+	return actions, nil
+}
+
+// Disruption records one injected failure for later correlation against the
+// latency/error time series.
+type Disruption struct {
+	Action    Action
+	Target    string
+	Timestamp time.Time
+}
+
+// Injector periodically injects one of a configured set of Actions against
+// the soak test's namespace, bounded by a concurrency budget so chaos never
+// outpaces the cluster's ability to recover.
+type Injector struct {
+	client  clientset.Interface
+	ns      string
+	actions []Action
+	budget  chan struct{}
+
+	rngMu sync.Mutex
+	rng   *rand.Rand
+
+	podNames  func() []string
+	nodeNames func() []string
+
+	rollingUpdateTarget *RollingUpdateTarget
+}
+
+// NewInjector returns an Injector that acts against ns using client, picking
+// targets from podNames/nodeNames at injection time (so callers can pass a
+// closure over a list that shrinks and regrows as pods churn). At most
+// budget disruptions run concurrently.
+func NewInjector(client clientset.Interface, ns string, actions []Action, budget int, podNames, nodeNames func() []string) *Injector {
+	if budget < 1 {
+		budget = 1
+	}
+	return &Injector{
+		client:    client,
+		ns:        ns,
+		actions:   actions,
+		budget:    make(chan struct{}, budget),
+		rng:       rand.New(rand.NewSource(time.Now().UnixNano())),
+		podNames:  podNames,
+		nodeNames: nodeNames,
+	}
+}
+
+// Run injects one random action from i.actions every interval until ctx is
+// canceled, sending each resulting Disruption to the returned channel. The
+// channel is closed once ctx is done and every in-flight injection has
+// returned.
+func (i *Injector) Run(ctx context.Context, interval time.Duration) <-chan Disruption {
+	disruptions := make(chan Disruption, 16)
+	go func() {
+		defer close(disruptions)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		var wg sync.WaitGroup
+		for {
+			select {
+			case <-ctx.Done():
+				wg.Wait()
+				return
+			case <-ticker.C:
+				select {
+				case i.budget <- struct{}{}:
+				default:
+					klog.V(2).Infof("Chaos budget exhausted, skipping this tick")
+					continue
+				}
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					defer func() { <-i.budget }()
+					d, err := i.injectOne(ctx)
+					if err != nil {
+						klog.Warningf("Chaos injection failed: %v", err)
+						return
+					}
+					select {
+					case disruptions <- d:
+					case <-ctx.Done():
+					}
+				}()
+			}
+		}
+	}()
+	return disruptions
+}
+
+// intn is a concurrency-safe substitute for rng.Intn, since Run invokes
+// injectOne (and the Action handlers it dispatches to) from up to budget
+// goroutines at once and *rand.Rand is not safe for concurrent use.
+func (i *Injector) intn(n int) int {
+	i.rngMu.Lock()
+	defer i.rngMu.Unlock()
+	return i.rng.Intn(n)
+}
+
+func (i *Injector) injectOne(ctx context.Context) (Disruption, error) {
+	if len(i.actions) == 0 {
+		return Disruption{}, fmt.Errorf("no chaos actions configured")
+	}
+	action := i.actions[i.intn(len(i.actions))]
+	switch action {
+	case ActionPodDelete:
+		return i.deleteRandomPod(ctx)
+	case ActionNodeCordon:
+		return i.cordonAndDrainRandomNode(ctx)
+	case ActionRollingUpdate:
+		return i.rollingUpdate(ctx)
+	default:
+		return Disruption{}, fmt.Errorf("unknown chaos action %q", action)
+	}
+}
@@ -0,0 +1,60 @@
+/*
+Copyright 2015 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package chaos
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/klog"
+)
+
+// cordonAndDrainRandomNode marks one randomly chosen node unschedulable and
+// deletes every soak pod running on it, the same sequence a real node
+// failure or `kubectl drain` would produce.
+func (i *Injector) cordonAndDrainRandomNode(ctx context.Context) (Disruption, error) {
+	nodes := i.nodeNames()
+	if len(nodes) == 0 {
+		return Disruption{}, fmt.Errorf("no nodes available to cordon")
+	}
+	name := nodes[i.intn(len(nodes))]
+
+	node, err := i.client.CoreV1().Nodes().Get(name, metav1.GetOptions{})
+	if err != nil {
+		return Disruption{}, fmt.Errorf("getting node %s: %w", name, err)
+	}
+	node.Spec.Unschedulable = true
+	if _, err := i.client.CoreV1().Nodes().Update(node); err != nil {
+		return Disruption{}, fmt.Errorf("cordoning node %s: %w", name, err)
+	}
+
+	pods, err := i.client.CoreV1().Pods(i.ns).List(metav1.ListOptions{
+		FieldSelector: fields.OneTermEqualSelector("spec.nodeName", name).String(),
+	})
+	if err != nil {
+		return Disruption{}, fmt.Errorf("listing pods on node %s: %w", name, err)
+	}
+	for _, pod := range pods.Items {
+		if err := i.client.CoreV1().Pods(i.ns).Delete(pod.Name, nil); err != nil {
+			klog.Warningf("Draining node %s: failed to delete pod %s: %v", name, pod.Name, err)
+		}
+	}
+	return Disruption{Action: ActionNodeCordon, Target: name, Timestamp: time.Now()}, nil
+}
@@ -0,0 +1,38 @@
+/*
+Copyright 2015 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package chaos
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// deleteRandomPod deletes one randomly chosen soak pod. The soak harness
+// does not recreate it, mirroring the failure a real pod crash or eviction
+// would cause.
+func (i *Injector) deleteRandomPod(ctx context.Context) (Disruption, error) {
+	pods := i.podNames()
+	if len(pods) == 0 {
+		return Disruption{}, fmt.Errorf("no pods available to delete")
+	}
+	name := pods[i.intn(len(pods))]
+	if err := i.client.CoreV1().Pods(i.ns).Delete(name, nil); err != nil {
+		return Disruption{}, fmt.Errorf("deleting pod %s/%s: %w", i.ns, name, err)
+	}
+	return Disruption{Action: ActionPodDelete, Target: name, Timestamp: time.Now()}, nil
+}
@@ -0,0 +1,109 @@
+/*
+Copyright 2015 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package chaos
+
+import (
+	"sync"
+	"time"
+)
+
+// outcome is one completed soak request, recorded so RecoveryTracker can
+// reconstruct a success-rate time series around each Disruption.
+type outcome struct {
+	at      time.Time
+	success bool
+}
+
+// RecoveryTracker records request outcomes over time and, given a
+// Disruption, estimates how long the success rate took to return to
+// baseline afterward - a real availability SLO number, rather than just an
+// aggregate QPS/error count for the whole run.
+type RecoveryTracker struct {
+	mu        sync.Mutex
+	outcomes  []outcome
+	window    time.Duration
+	retention time.Duration
+}
+
+// NewRecoveryTracker returns a RecoveryTracker that buckets outcomes into
+// windows of the given size when computing success rates. Outcomes older
+// than retention (relative to the most recently recorded one) are dropped on
+// the next Record, so a soak test run with --up_to -1 doesn't grow this
+// tracker's memory without bound; retention must cover however far back of a
+// baseline and however far forward of a search a caller's RecoveryTime calls
+// need, or those calls will stop finding data for old Disruptions.
+func NewRecoveryTracker(window, retention time.Duration) *RecoveryTracker {
+	return &RecoveryTracker{window: window, retention: retention}
+}
+
+// Record adds one completed request's outcome, then evicts outcomes older
+// than t.retention.
+func (t *RecoveryTracker) Record(at time.Time, success bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.outcomes = append(t.outcomes, outcome{at: at, success: success})
+	if t.retention <= 0 {
+		return
+	}
+	cutoff := at.Add(-t.retention)
+	i := 0
+	for i < len(t.outcomes) && t.outcomes[i].at.Before(cutoff) {
+		i++
+	}
+	if i > 0 {
+		t.outcomes = append(t.outcomes[:0], t.outcomes[i:]...)
+	}
+}
+
+// SuccessRate returns the fraction of requests that succeeded in [since,
+// since+t.window). It returns (0, false) if no requests were recorded in
+// that window.
+func (t *RecoveryTracker) SuccessRate(since time.Time) (float64, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	until := since.Add(t.window)
+	var total, ok int
+	for _, o := range t.outcomes {
+		if o.at.Before(since) || !o.at.Before(until) {
+			continue
+		}
+		total++
+		if o.success {
+			ok++
+		}
+	}
+	if total == 0 {
+		return 0, false
+	}
+	return float64(ok) / float64(total), true
+}
+
+// RecoveryTime scans forward in t.window-sized steps from d.Timestamp and
+// returns how long the success rate took to climb back to at least
+// threshold*baseline, sustained for one full window. It returns
+// (0, false) if recovery wasn't observed within searchLimit.
+func (t *RecoveryTracker) RecoveryTime(d Disruption, baseline, threshold float64, searchLimit time.Duration) (time.Duration, bool) {
+	target := baseline * threshold
+	deadline := d.Timestamp.Add(searchLimit)
+	for cursor := d.Timestamp; cursor.Before(deadline); cursor = cursor.Add(t.window) {
+		rate, ok := t.SuccessRate(cursor)
+		if ok && rate >= target {
+			return cursor.Sub(d.Timestamp), true
+		}
+	}
+	return 0, false
+}
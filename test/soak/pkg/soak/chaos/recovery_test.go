@@ -0,0 +1,92 @@
+/*
+Copyright 2015 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package chaos
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRecoveryTrackerSuccessRate(t *testing.T) {
+	tr := NewRecoveryTracker(10*time.Second, 0)
+	start := time.Unix(0, 0)
+	tr.Record(start, true)
+	tr.Record(start.Add(time.Second), true)
+	tr.Record(start.Add(2*time.Second), false)
+
+	rate, ok := tr.SuccessRate(start)
+	if !ok {
+		t.Fatalf("SuccessRate(%v) = (_, false), want ok", start)
+	}
+	if want := 2.0 / 3.0; rate != want {
+		t.Errorf("SuccessRate(%v) = %v, want %v", start, rate, want)
+	}
+
+	if _, ok := tr.SuccessRate(start.Add(time.Hour)); ok {
+		t.Errorf("SuccessRate for a window with no outcomes = ok, want !ok")
+	}
+}
+
+func TestRecoveryTrackerRecoveryTime(t *testing.T) {
+	const window = 10 * time.Second
+	tr := NewRecoveryTracker(window, 0)
+	d := Disruption{Action: ActionPodDelete, Target: "pod-0", Timestamp: time.Unix(0, 0)}
+
+	// Degraded for the first two windows after the disruption, then back to
+	// a clean 100% success rate from the third window on.
+	for i := 0; i < 40; i++ {
+		at := d.Timestamp.Add(time.Duration(i) * time.Second)
+		success := at.Sub(d.Timestamp) >= 2*window
+		tr.Record(at, success)
+	}
+
+	got, ok := tr.RecoveryTime(d, 1.0, 0.95, time.Minute)
+	if !ok {
+		t.Fatalf("RecoveryTime() = (_, false), want a recovery to be found")
+	}
+	if got != 2*window {
+		t.Errorf("RecoveryTime() = %v, want %v", got, 2*window)
+	}
+}
+
+func TestRecoveryTrackerRecoveryTimeNeverRecovers(t *testing.T) {
+	const window = 10 * time.Second
+	tr := NewRecoveryTracker(window, 0)
+	d := Disruption{Action: ActionPodDelete, Target: "pod-0", Timestamp: time.Unix(0, 0)}
+
+	for i := 0; i < 20; i++ {
+		tr.Record(d.Timestamp.Add(time.Duration(i)*time.Second), false)
+	}
+
+	if _, ok := tr.RecoveryTime(d, 1.0, 0.95, 3*window); ok {
+		t.Errorf("RecoveryTime() for a success rate that never recovers = ok, want !ok")
+	}
+}
+
+func TestRecoveryTrackerRetentionEvictsOldOutcomes(t *testing.T) {
+	const window = time.Second
+	const retention = 5 * time.Second
+	tr := NewRecoveryTracker(window, retention)
+	start := time.Unix(0, 0)
+
+	tr.Record(start, true)
+	tr.Record(start.Add(retention+time.Second), true)
+
+	if _, ok := tr.SuccessRate(start); ok {
+		t.Errorf("SuccessRate(%v) found an outcome older than the retention window, want it evicted", start)
+	}
+}
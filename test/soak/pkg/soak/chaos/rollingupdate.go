@@ -0,0 +1,87 @@
+/*
+Copyright 2015 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package chaos
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/rand"
+)
+
+// RollingUpdateTarget is the ReplicationController the rolling-update chaos
+// action rolls, along with the image it should roll to. It is set once via
+// Injector.SetRollingUpdateTarget before Run starts; if it is never set, the
+// rolling-update action is skipped with a warning.
+type RollingUpdateTarget struct {
+	RC    *corev1.ReplicationController
+	Image string
+}
+
+// SetRollingUpdateTarget registers the ReplicationController the
+// rolling-update action should roll forward. Pass the RC currently backing
+// the soak pods; an image differing from its current one is substituted in
+// each time the action fires.
+func (i *Injector) SetRollingUpdateTarget(target RollingUpdateTarget) {
+	i.rollingUpdateTarget = &target
+}
+
+// rollingUpdate builds a new ReplicationController from the registered
+// target's current spec with a hash-suffixed name/selector, in the same
+// spirit as the classic `kubectl rolling-update`: rather than mutating the
+// running RC in place, stand up a distinct one so old and new pods can
+// briefly coexist, then retire the old RC.
+func (i *Injector) rollingUpdate(ctx context.Context) (Disruption, error) {
+	if i.rollingUpdateTarget == nil {
+		return Disruption{}, fmt.Errorf("rolling-update chaos action requested but no RollingUpdateTarget was configured")
+	}
+	old := i.rollingUpdateTarget.RC
+
+	current, err := i.client.CoreV1().ReplicationControllers(i.ns).Get(old.Name, metav1.GetOptions{})
+	if err != nil {
+		return Disruption{}, fmt.Errorf("getting replication controller %s: %w", old.Name, err)
+	}
+
+	next := current.DeepCopy()
+	hash := rand.String(8)
+	next.Name = fmt.Sprintf("%s-%s", current.Name, hash)
+	next.ResourceVersion = ""
+	if next.Spec.Selector == nil {
+		next.Spec.Selector = map[string]string{}
+	}
+	next.Spec.Selector["rollout-hash"] = hash
+	if next.Spec.Template.Labels == nil {
+		next.Spec.Template.Labels = map[string]string{}
+	}
+	next.Spec.Template.Labels["rollout-hash"] = hash
+	for c := range next.Spec.Template.Spec.Containers {
+		next.Spec.Template.Spec.Containers[c].Image = i.rollingUpdateTarget.Image
+	}
+
+	if _, err := i.client.CoreV1().ReplicationControllers(i.ns).Create(next); err != nil {
+		return Disruption{}, fmt.Errorf("creating replacement replication controller %s: %w", next.Name, err)
+	}
+	if err := i.client.CoreV1().ReplicationControllers(i.ns).Delete(current.Name, nil); err != nil {
+		return Disruption{}, fmt.Errorf("deleting old replication controller %s: %w", current.Name, err)
+	}
+	i.rollingUpdateTarget.RC = next
+
+	return Disruption{Action: ActionRollingUpdate, Target: next.Name, Timestamp: time.Now()}, nil
+}
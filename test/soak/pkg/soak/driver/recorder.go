@@ -0,0 +1,61 @@
+/*
+Copyright 2015 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package driver
+
+import (
+	"time"
+
+	"k8s.io/kubernetes/test/soak/pkg/soak"
+)
+
+// LatencyRecorder tracks both halves of request latency under an open-loop
+// workload: service time, which only covers the request itself, and
+// response time, which also counts however long the request sat queued
+// behind a busy worker pool. Reporting only service time is exactly the
+// coordinated-omission bug this package exists to avoid.
+type LatencyRecorder struct {
+	serviceTime  *soak.Histogram
+	responseTime *soak.Histogram
+}
+
+// NewLatencyRecorder returns an empty LatencyRecorder.
+func NewLatencyRecorder() *LatencyRecorder {
+	return &LatencyRecorder{
+		serviceTime:  soak.NewHistogram(),
+		responseTime: soak.NewHistogram(),
+	}
+}
+
+// Record adds one completed request. intendedStart is when the Scheduler
+// meant to start it, actualStart is when a worker became free to run it, and
+// done is when it completed.
+func (r *LatencyRecorder) Record(intendedStart, actualStart, done time.Time) {
+	r.serviceTime.Record(done.Sub(actualStart))
+	r.responseTime.Record(done.Sub(intendedStart))
+}
+
+// ServiceTime is the distribution of actualStart-to-done durations: how long
+// the request itself took once a worker picked it up.
+func (r *LatencyRecorder) ServiceTime() *soak.Histogram {
+	return r.serviceTime
+}
+
+// ResponseTime is the distribution of intendedStart-to-done durations: how
+// long a caller would actually have waited, including queueing delay.
+func (r *LatencyRecorder) ResponseTime() *soak.Histogram {
+	return r.responseTime
+}
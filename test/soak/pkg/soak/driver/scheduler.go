@@ -0,0 +1,108 @@
+/*
+Copyright 2015 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package driver implements an open-loop (coordinated-omission-free) workload
+// driver for the cauldron soak test: requests are scheduled on a wall clock
+// at a target arrival rate, independent of how long previous requests took
+// to complete, so queueing delay shows up in the recorded latency instead of
+// being hidden by a closed request/response loop.
+package driver
+
+import (
+	"context"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// Scheduler emits request start times as a Poisson arrival process at a
+// fixed target rate and hands each one to a bounded worker pool. Requests
+// that cannot start on time (because every worker is busy) still fire as
+// soon as a worker frees up, with their original intended start time intact,
+// so callers can measure the resulting queueing delay.
+type Scheduler struct {
+	targetQPS   float64
+	maxInFlight int
+	rng         *rand.Rand
+}
+
+// NewScheduler returns a Scheduler that targets targetQPS arrivals per
+// second using at most maxInFlight concurrent workers.
+func NewScheduler(targetQPS float64, maxInFlight int) *Scheduler {
+	if maxInFlight < 1 {
+		maxInFlight = 1
+	}
+	return &Scheduler{
+		targetQPS:   targetQPS,
+		maxInFlight: maxInFlight,
+		rng:         rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+}
+
+// Fire is called once per scheduled request with the wall-clock time the
+// request was supposed to start (intendedStart) and the time a worker
+// actually became available to run it (actualStart). Fire is responsible for
+// issuing the request and should block until it completes.
+type Fire func(ctx context.Context, intendedStart, actualStart time.Time)
+
+// Run schedules n requests (or until ctx is canceled if n <= 0) as a Poisson
+// arrival process and dispatches each to fire via the bounded worker pool.
+// Run blocks until every dispatched request's fire call has returned.
+func (s *Scheduler) Run(ctx context.Context, n int, fire Fire) {
+	inFlight := make(chan struct{}, s.maxInFlight)
+	var wg sync.WaitGroup
+
+	next := time.Now()
+	for i := 0; n <= 0 || i < n; i++ {
+		if ctx.Err() != nil {
+			break
+		}
+		if wait := time.Until(next); wait > 0 {
+			timer := time.NewTimer(wait)
+			select {
+			case <-timer.C:
+			case <-ctx.Done():
+				timer.Stop()
+				goto drain
+			}
+		}
+		intendedStart := next
+		next = next.Add(s.nextInterval())
+
+		select {
+		case inFlight <- struct{}{}:
+		case <-ctx.Done():
+			goto drain
+		}
+		wg.Add(1)
+		go func(intended time.Time) {
+			defer wg.Done()
+			defer func() { <-inFlight }()
+			fire(ctx, intended, time.Now())
+		}(intendedStart)
+	}
+drain:
+	wg.Wait()
+}
+
+// nextInterval draws the next exponentially distributed inter-arrival
+// interval for a Poisson process at targetQPS.
+func (s *Scheduler) nextInterval() time.Duration {
+	if s.targetQPS <= 0 {
+		return 0
+	}
+	return time.Duration(s.rng.ExpFloat64() / s.targetQPS * float64(time.Second))
+}
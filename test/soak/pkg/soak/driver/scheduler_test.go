@@ -0,0 +1,54 @@
+/*
+Copyright 2015 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package driver
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSchedulerNextIntervalZeroQPS(t *testing.T) {
+	s := NewScheduler(0, 1)
+	if got := s.nextInterval(); got != 0 {
+		t.Errorf("nextInterval() with targetQPS=0 = %v, want 0", got)
+	}
+}
+
+func TestSchedulerNextIntervalMeanApproachesTargetRate(t *testing.T) {
+	const targetQPS = 50.0
+	s := NewScheduler(targetQPS, 1)
+
+	const n = 20000
+	var total time.Duration
+	for i := 0; i < n; i++ {
+		d := s.nextInterval()
+		if d < 0 {
+			t.Fatalf("nextInterval() returned negative duration %v", d)
+		}
+		total += d
+	}
+
+	wantMean := time.Duration(float64(time.Second) / targetQPS)
+	gotMean := total / n
+	// A Poisson arrival process's inter-arrival times are exponentially
+	// distributed with mean 1/targetQPS; over enough draws the sample mean
+	// should land within a generous tolerance of that.
+	tolerance := wantMean / 5
+	if diff := gotMean - wantMean; diff < -tolerance || diff > tolerance {
+		t.Errorf("mean of %d draws = %v, want within %v of %v", n, gotMean, tolerance, wantMean)
+	}
+}
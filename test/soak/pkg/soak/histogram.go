@@ -0,0 +1,106 @@
+/*
+Copyright 2015 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package soak
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// histogramBoundaries are the upper bounds, in ascending order, of the
+// latency histogram's buckets. They are spaced geometrically (10% growth)
+// between 100µs and 2 minutes, which keeps relative error low across the
+// microsecond-to-multi-second range a soak test needs without the bookkeeping
+// of a full HDR histogram implementation.
+var histogramBoundaries = buildHistogramBoundaries(100*time.Microsecond, 2*time.Minute, 1.1)
+
+func buildHistogramBoundaries(lowest, highest time.Duration, growth float64) []time.Duration {
+	var boundaries []time.Duration
+	for b := float64(lowest); b < float64(highest); b *= growth {
+		boundaries = append(boundaries, time.Duration(b))
+	}
+	return append(boundaries, highest)
+}
+
+// Histogram is a bucketed, concurrency-safe latency histogram. Values above
+// the highest boundary are clamped into the last bucket so Percentile always
+// returns a finite duration.
+type Histogram struct {
+	mu         sync.Mutex
+	counts     []int64
+	totalCount int64
+	sum        time.Duration
+}
+
+// NewHistogram returns an empty latency histogram.
+func NewHistogram() *Histogram {
+	return &Histogram{counts: make([]int64, len(histogramBoundaries))}
+}
+
+// Record adds one observed latency to the histogram.
+func (h *Histogram) Record(d time.Duration) {
+	idx := sort.Search(len(histogramBoundaries), func(i int) bool { return histogramBoundaries[i] >= d })
+	if idx == len(histogramBoundaries) {
+		idx = len(histogramBoundaries) - 1
+	}
+	h.mu.Lock()
+	h.counts[idx]++
+	h.totalCount++
+	h.sum += d
+	h.mu.Unlock()
+}
+
+// Count returns the number of observations recorded.
+func (h *Histogram) Count() int64 {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.totalCount
+}
+
+// Mean returns the arithmetic mean of all recorded latencies.
+func (h *Histogram) Mean() time.Duration {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.totalCount == 0 {
+		return 0
+	}
+	return h.sum / time.Duration(h.totalCount)
+}
+
+// Percentile returns the smallest recorded-bucket boundary at or above the
+// requested percentile (0-100). It returns 0 if no observations were
+// recorded.
+func (h *Histogram) Percentile(p float64) time.Duration {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.totalCount == 0 {
+		return 0
+	}
+	target := int64(p / 100 * float64(h.totalCount))
+	if target >= h.totalCount {
+		target = h.totalCount - 1
+	}
+	var cumulative int64
+	for i, c := range h.counts {
+		cumulative += c
+		if cumulative > target {
+			return histogramBoundaries[i]
+		}
+	}
+	return histogramBoundaries[len(histogramBoundaries)-1]
+}
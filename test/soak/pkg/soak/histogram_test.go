@@ -0,0 +1,80 @@
+/*
+Copyright 2015 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package soak
+
+import (
+	"testing"
+	"time"
+)
+
+func TestHistogramPercentileEmpty(t *testing.T) {
+	h := NewHistogram()
+	if got := h.Percentile(50); got != 0 {
+		t.Errorf("Percentile(50) on empty histogram = %v, want 0", got)
+	}
+	if got := h.Mean(); got != 0 {
+		t.Errorf("Mean() on empty histogram = %v, want 0", got)
+	}
+}
+
+func TestHistogramPercentile(t *testing.T) {
+	h := NewHistogram()
+	// 100 observations spread evenly from 1ms to 100ms: the pth percentile
+	// should land on roughly the bucket containing the p-th millisecond.
+	for i := 1; i <= 100; i++ {
+		h.Record(time.Duration(i) * time.Millisecond)
+	}
+
+	tests := []struct {
+		percentile  float64
+		wantAtLeast time.Duration
+		wantAtMost  time.Duration
+	}{
+		{percentile: 0, wantAtLeast: time.Millisecond, wantAtMost: 2 * time.Millisecond},
+		{percentile: 50, wantAtLeast: 49 * time.Millisecond, wantAtMost: 55 * time.Millisecond},
+		{percentile: 99, wantAtLeast: 98 * time.Millisecond, wantAtMost: 106 * time.Millisecond},
+		{percentile: 100, wantAtLeast: 99 * time.Millisecond, wantAtMost: 106 * time.Millisecond},
+	}
+	for _, tt := range tests {
+		got := h.Percentile(tt.percentile)
+		if got < tt.wantAtLeast || got > tt.wantAtMost {
+			t.Errorf("Percentile(%v) = %v, want in [%v, %v]", tt.percentile, got, tt.wantAtLeast, tt.wantAtMost)
+		}
+	}
+}
+
+func TestHistogramClampsAboveHighestBoundary(t *testing.T) {
+	h := NewHistogram()
+	h.Record(time.Hour)
+	want := histogramBoundaries[len(histogramBoundaries)-1]
+	if got := h.Percentile(100); got != want {
+		t.Errorf("Percentile(100) after recording above the highest boundary = %v, want clamp to %v", got, want)
+	}
+}
+
+func TestHistogramMean(t *testing.T) {
+	h := NewHistogram()
+	h.Record(10 * time.Millisecond)
+	h.Record(20 * time.Millisecond)
+	h.Record(30 * time.Millisecond)
+	if got, want := h.Mean(), 20*time.Millisecond; got != want {
+		t.Errorf("Mean() = %v, want %v", got, want)
+	}
+	if got, want := h.Count(), int64(3); got != want {
+		t.Errorf("Count() = %v, want %v", got, want)
+	}
+}
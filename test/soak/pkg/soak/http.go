@@ -0,0 +1,57 @@
+/*
+Copyright 2015 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package soak
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+)
+
+// httpGetGenerator fires one bare http.Get per request, the behavior the
+// soak test had before load generators became pluggable: a new connection
+// (or one from the default transport's pool) for every query.
+type httpGetGenerator struct {
+	target Target
+}
+
+// NewHTTPGetGenerator returns a LoadGenerator that issues a plain
+// http.Get against target for every request.
+func NewHTTPGetGenerator(target Target) LoadGenerator {
+	return &httpGetGenerator{target: target}
+}
+
+func (g *httpGetGenerator) Name() string {
+	return "get"
+}
+
+func (g *httpGetGenerator) Fire(ctx context.Context) (Response, error) {
+	req, err := http.NewRequest(http.MethodGet, string(g.target), nil)
+	if err != nil {
+		return Response{}, err
+	}
+	resp, err := http.DefaultClient.Do(req.WithContext(ctx))
+	if err != nil {
+		return Response{}, err
+	}
+	defer resp.Body.Close()
+	hostname, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return Response{}, err
+	}
+	return Response{Hostname: string(hostname)}, nil
+}
@@ -0,0 +1,72 @@
+/*
+Copyright 2015 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package soak
+
+import (
+	"context"
+	"crypto/tls"
+	"io/ioutil"
+	"net"
+	"net/http"
+
+	"golang.org/x/net/http2"
+)
+
+// http2Generator fires requests over a multiplexed HTTP/2 connection. The
+// serve-hostname service only speaks plain HTTP, so this uses h2c (HTTP/2
+// over cleartext) via http2.Transport.AllowHTTP with a plain TCP dial in
+// place of DialTLS.
+type http2Generator struct {
+	target Target
+	client *http.Client
+}
+
+// NewHTTP2Generator returns a LoadGenerator that multiplexes requests over a
+// single HTTP/2 (h2c) connection to target.
+func NewHTTP2Generator(target Target) LoadGenerator {
+	transport := &http2.Transport{
+		AllowHTTP: true,
+		DialTLS: func(network, addr string, cfg *tls.Config) (net.Conn, error) {
+			return net.Dial(network, addr)
+		},
+	}
+	return &http2Generator{
+		target: target,
+		client: &http.Client{Transport: transport},
+	}
+}
+
+func (g *http2Generator) Name() string {
+	return "http2"
+}
+
+func (g *http2Generator) Fire(ctx context.Context) (Response, error) {
+	req, err := http.NewRequest(http.MethodGet, string(g.target), nil)
+	if err != nil {
+		return Response{}, err
+	}
+	resp, err := g.client.Do(req.WithContext(ctx))
+	if err != nil {
+		return Response{}, err
+	}
+	defer resp.Body.Close()
+	hostname, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return Response{}, err
+	}
+	return Response{Hostname: string(hostname)}, nil
+}
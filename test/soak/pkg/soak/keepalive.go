@@ -0,0 +1,70 @@
+/*
+Copyright 2015 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package soak
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+)
+
+// keepAliveGenerator reuses a small pool of persistent HTTP/1.1 connections,
+// so the recorded latency reflects steady-state request handling rather than
+// per-request TCP/TLS setup.
+type keepAliveGenerator struct {
+	target Target
+	client *http.Client
+}
+
+// NewKeepAliveGenerator returns a LoadGenerator backed by an http.Client
+// whose Transport reuses up to maxConnsPerHost persistent connections.
+func NewKeepAliveGenerator(target Target, maxConnsPerHost int) LoadGenerator {
+	if maxConnsPerHost < 1 {
+		maxConnsPerHost = 1
+	}
+	return &keepAliveGenerator{
+		target: target,
+		client: &http.Client{
+			Transport: &http.Transport{
+				MaxIdleConns:        maxConnsPerHost,
+				MaxIdleConnsPerHost: maxConnsPerHost,
+				DisableKeepAlives:   false,
+			},
+		},
+	}
+}
+
+func (g *keepAliveGenerator) Name() string {
+	return "keepalive"
+}
+
+func (g *keepAliveGenerator) Fire(ctx context.Context) (Response, error) {
+	req, err := http.NewRequest(http.MethodGet, string(g.target), nil)
+	if err != nil {
+		return Response{}, err
+	}
+	resp, err := g.client.Do(req.WithContext(ctx))
+	if err != nil {
+		return Response{}, err
+	}
+	defer resp.Body.Close()
+	hostname, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return Response{}, err
+	}
+	return Response{Hostname: string(hostname)}, nil
+}
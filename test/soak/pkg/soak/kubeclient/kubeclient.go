@@ -0,0 +1,159 @@
+/*
+Copyright 2015 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package kubeclient wraps clientset.Interface calls with a configurable
+// exponential-backoff-with-jitter retry policy, so every soak test call site
+// gets consistent, tunable retry semantics instead of a hand-rolled
+// `for start := time.Now(); time.Since(start) < timeout; ...` loop with its
+// own sleep interval. Call sites vary their deadline per operation (listing
+// nodes, creating a namespace, deleting a pod, ...), so WithRetries takes a
+// RetryPolicy per call rather than fixing one on a wrapped client.
+package kubeclient
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/klog"
+)
+
+// RetryPolicy configures the backoff WithRetries uses and, separately, which
+// errors are even worth retrying.
+type RetryPolicy struct {
+	// InitialBackoff is the delay before the second attempt.
+	InitialBackoff time.Duration
+	// MaxBackoff caps how long any single backoff step can grow to.
+	MaxBackoff time.Duration
+	// BackoffFactor is the multiplier applied to the backoff after each
+	// failed attempt.
+	BackoffFactor float64
+	// Jitter is the fraction of the computed backoff randomly added on top,
+	// so many concurrent callers don't retry in lockstep.
+	Jitter float64
+	// Deadline bounds the total time WithRetries spends on one operation,
+	// across every attempt.
+	Deadline time.Duration
+}
+
+// DefaultRetryPolicy returns the policy the soak test used to approximate
+// by hand: a 1s initial backoff doubling up to 30s with light jitter,
+// bounded by deadline.
+func DefaultRetryPolicy(deadline time.Duration) RetryPolicy {
+	return RetryPolicy{
+		InitialBackoff: time.Second,
+		MaxBackoff:     30 * time.Second,
+		BackoffFactor:  2.0,
+		Jitter:         0.2,
+		Deadline:       deadline,
+	}
+}
+
+// FixedIntervalRetryPolicy returns a RetryPolicy with no backoff growth -
+// every attempt waits the same interval, plus jitter - for PollWithRetries
+// callers that are polling a condition at a steady cadence rather than
+// retrying a call that itself failed.
+func FixedIntervalRetryPolicy(interval, deadline time.Duration) RetryPolicy {
+	return RetryPolicy{
+		InitialBackoff: interval,
+		MaxBackoff:     interval,
+		BackoffFactor:  1,
+		Jitter:         0.2,
+		Deadline:       deadline,
+	}
+}
+
+// IsRetryable reports whether err represents a transient condition worth
+// retrying (timeouts, rate limiting, a passing server hiccup) as opposed to
+// one that will never succeed no matter how many times it's retried. A 404
+// is terminal in that sense too: retrying a Get/Delete against an object
+// that's gone (e.g. a pod chaos already deleted) can't turn into anything
+// but another 404, so callers that want "already gone" treated as success
+// must check errors.IsNotFound themselves rather than relying on a retry to
+// paper over it.
+func IsRetryable(err error) bool {
+	if err == nil {
+		return false
+	}
+	if errors.IsInvalid(err) || errors.IsForbidden(err) || errors.IsMethodNotSupported(err) || errors.IsBadRequest(err) || errors.IsNotFound(err) {
+		return false
+	}
+	return true
+}
+
+// WithRetries runs op, retrying with exponential backoff and jitter per
+// policy until it succeeds, op returns a non-retryable error, or
+// policy.Deadline elapses.
+func WithRetries(ctx context.Context, policy RetryPolicy, op func() error) error {
+	ctx, cancel := context.WithTimeout(ctx, policy.Deadline)
+	defer cancel()
+
+	backoff := wait.Backoff{
+		Duration: policy.InitialBackoff,
+		Factor:   policy.BackoffFactor,
+		Jitter:   policy.Jitter,
+		Cap:      policy.MaxBackoff,
+		Steps:    math.MaxInt32,
+	}
+
+	var lastErr error
+	waitErr := wait.ExponentialBackoffWithContext(ctx, backoff, func() (bool, error) {
+		lastErr = op()
+		if lastErr == nil {
+			return true, nil
+		}
+		if !IsRetryable(lastErr) {
+			return false, lastErr
+		}
+		klog.V(4).Infof("Retryable error, backing off: %v", lastErr)
+		return false, nil
+	})
+	if waitErr == nil {
+		return nil
+	}
+	if waitErr == wait.ErrWaitTimeout {
+		return fmt.Errorf("giving up after %v: %w", policy.Deadline, lastErr)
+	}
+	return waitErr
+}
+
+// PollWithRetries polls cond with exponential backoff and jitter per policy
+// until cond reports done, returns an error, or policy.Deadline elapses.
+// Unlike WithRetries, cond reports readiness directly instead of through an
+// error, for call sites polling a field or condition (a pod turning Running,
+// a Service's endpoints propagating, a namespace finishing deletion) rather
+// than retrying a call that itself failed.
+func PollWithRetries(ctx context.Context, policy RetryPolicy, cond func() (bool, error)) error {
+	ctx, cancel := context.WithTimeout(ctx, policy.Deadline)
+	defer cancel()
+
+	backoff := wait.Backoff{
+		Duration: policy.InitialBackoff,
+		Factor:   policy.BackoffFactor,
+		Jitter:   policy.Jitter,
+		Cap:      policy.MaxBackoff,
+		Steps:    math.MaxInt32,
+	}
+
+	waitErr := wait.ExponentialBackoffWithContext(ctx, backoff, cond)
+	if waitErr == wait.ErrWaitTimeout {
+		return fmt.Errorf("giving up after %v", policy.Deadline)
+	}
+	return waitErr
+}
@@ -0,0 +1,73 @@
+/*
+Copyright 2015 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubeclient
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func TestIsRetryable(t *testing.T) {
+	gr := schema.GroupResource{Group: "", Resource: "pods"}
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{name: "nil", err: nil, want: false},
+		{name: "plain error", err: errors.New("boom"), want: true},
+		{name: "not found", err: apierrors.NewNotFound(gr, "foo"), want: false},
+		{name: "conflict", err: apierrors.NewConflict(gr, "foo", errors.New("conflict")), want: true},
+		{name: "server timeout", err: apierrors.NewServerTimeout(gr, "get", 1), want: true},
+		{name: "too many requests", err: apierrors.NewTooManyRequests("slow down", 1), want: true},
+		{name: "invalid", err: apierrors.NewInvalid(schema.GroupKind{Group: "", Kind: "Pod"}, "foo", nil), want: false},
+		{name: "forbidden", err: apierrors.NewForbidden(gr, "foo", errors.New("forbidden")), want: false},
+		{name: "method not supported", err: apierrors.NewMethodNotSupported(gr, "PATCH"), want: false},
+		{name: "bad request", err: apierrors.NewBadRequest("bad"), want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsRetryable(tt.err); got != tt.want {
+				t.Errorf("IsRetryable(%v) = %v, want %v", tt.err, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDefaultRetryPolicy(t *testing.T) {
+	p := DefaultRetryPolicy(30 * time.Second)
+	if p.BackoffFactor != 2.0 {
+		t.Errorf("DefaultRetryPolicy().BackoffFactor = %v, want 2.0", p.BackoffFactor)
+	}
+	if p.Deadline != 30*time.Second {
+		t.Errorf("DefaultRetryPolicy().Deadline = %v, want 30s", p.Deadline)
+	}
+}
+
+func TestFixedIntervalRetryPolicyHasNoBackoffGrowth(t *testing.T) {
+	p := FixedIntervalRetryPolicy(5*time.Second, 100*time.Second)
+	if p.InitialBackoff != p.MaxBackoff {
+		t.Errorf("FixedIntervalRetryPolicy: InitialBackoff (%v) != MaxBackoff (%v), want equal", p.InitialBackoff, p.MaxBackoff)
+	}
+	if p.BackoffFactor != 1 {
+		t.Errorf("FixedIntervalRetryPolicy().BackoffFactor = %v, want 1", p.BackoffFactor)
+	}
+}
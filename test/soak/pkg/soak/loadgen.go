@@ -0,0 +1,64 @@
+/*
+Copyright 2015 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package soak provides the building blocks used by the cauldron soak test:
+// pluggable load generators that talk to the serve-hostname service and the
+// latency histograms the driver uses to summarize each iteration.
+package soak
+
+import (
+	"context"
+	"fmt"
+)
+
+// Response is what a LoadGenerator returns for a single request it fired.
+type Response struct {
+	// Hostname is the body of the serve-hostname response, identifying the
+	// pod that answered the request.
+	Hostname string
+}
+
+// LoadGenerator issues a single request against the soak target and reports
+// what came back. The driver shares one LoadGenerator across all of its
+// workers, so Fire is called concurrently and implementations must be safe
+// for concurrent use.
+type LoadGenerator interface {
+	// Fire issues one request and blocks until it completes or ctx is done.
+	Fire(ctx context.Context) (Response, error)
+	// Name identifies the load generator, e.g. for inclusion in reports.
+	Name() string
+}
+
+// Target is the base URL of the serve-hostnames service, e.g.
+// "http://serve-hostnames.<ns>:9376".
+type Target string
+
+// NewLoadGenerator constructs the LoadGenerator selected by name. Valid names
+// are "get", "keepalive", "http2" and "tcp".
+func NewLoadGenerator(name string, target Target) (LoadGenerator, error) {
+	switch name {
+	case "", "get":
+		return NewHTTPGetGenerator(target), nil
+	case "keepalive":
+		return NewKeepAliveGenerator(target, 1), nil
+	case "http2":
+		return NewHTTP2Generator(target), nil
+	case "tcp":
+		return NewTCPGenerator(target), nil
+	default:
+		return nil, fmt.Errorf("unknown load generator %q", name)
+	}
+}
@@ -0,0 +1,77 @@
+/*
+Copyright 2015 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package protocoltarget
+
+import (
+	"context"
+	"fmt"
+	"net"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/kubernetes/test/soak/pkg/soak"
+)
+
+// dnsTarget reuses the ordinary serve-hostname pods and Service, but probes
+// kube-dns's resolution of the Service's cluster-internal name instead of
+// talking to the pods directly. This is the one axis today's soak test
+// doesn't exercise at all: service-discovery latency.
+type dnsTarget struct{}
+
+func (dnsTarget) Name() string {
+	return "dns"
+}
+
+func (dnsTarget) Container() corev1.Container {
+	return httpTarget{}.Container()
+}
+
+func (dnsTarget) ServicePort() corev1.ServicePort {
+	return httpTarget{}.ServicePort()
+}
+
+func (dnsTarget) AttributesToPod() bool {
+	return false
+}
+
+func (dnsTarget) NewProbe(serviceHost, ns, loadGeneratorName string) (soak.LoadGenerator, error) {
+	return &dnsLookupGenerator{
+		query:    fmt.Sprintf("serve-hostnames.%s.svc.cluster.local", ns),
+		resolver: &net.Resolver{},
+	}, nil
+}
+
+// dnsLookupGenerator resolves the soak Service's cluster-internal DNS name
+// on every Fire, recording however long kube-dns took to answer.
+type dnsLookupGenerator struct {
+	query    string
+	resolver *net.Resolver
+}
+
+func (g *dnsLookupGenerator) Name() string {
+	return "dns"
+}
+
+func (g *dnsLookupGenerator) Fire(ctx context.Context) (soak.Response, error) {
+	addrs, err := g.resolver.LookupHost(ctx, g.query)
+	if err != nil {
+		return soak.Response{}, err
+	}
+	if len(addrs) == 0 {
+		return soak.Response{}, fmt.Errorf("no addresses returned for %s", g.query)
+	}
+	return soak.Response{Hostname: addrs[0]}, nil
+}
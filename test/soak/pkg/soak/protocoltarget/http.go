@@ -0,0 +1,67 @@
+/*
+Copyright 2015 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package protocoltarget
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/kubernetes/test/e2e/framework"
+	"k8s.io/kubernetes/test/soak/pkg/soak"
+)
+
+// httpPort is the port the serve-hostname container and Service both use,
+// matching the original cauldron soak test.
+const httpPort = 9376
+
+// httpTarget runs the original serve-hostname container and probes it with
+// a plain HTTP GET.
+//
+// There is no TLS-terminating variant of serve-hostname (no cert, no HTTPS
+// listener), so this target does not accept a "tls" flavor (nor does any
+// other target); "https" stays out of the accepted --target values in New until one
+// exists.
+type httpTarget struct{}
+
+func (t httpTarget) Name() string {
+	return "http"
+}
+
+func (t httpTarget) Container() corev1.Container {
+	return corev1.Container{
+		Name:  "serve-hostname",
+		Image: framework.ServeHostnameImage,
+		Ports: []corev1.ContainerPort{{ContainerPort: httpPort}},
+	}
+}
+
+func (t httpTarget) ServicePort() corev1.ServicePort {
+	return corev1.ServicePort{
+		Protocol:   corev1.ProtocolTCP,
+		Port:       httpPort,
+		TargetPort: intstr.FromInt(httpPort),
+	}
+}
+
+func (t httpTarget) AttributesToPod() bool {
+	return true
+}
+
+func (t httpTarget) NewProbe(serviceHost, ns, loadGeneratorName string) (soak.LoadGenerator, error) {
+	return soak.NewLoadGenerator(loadGeneratorName, soak.Target(fmt.Sprintf("http://%s:%d", serviceHost, httpPort)))
+}
@@ -0,0 +1,74 @@
+/*
+Copyright 2015 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package protocoltarget decouples the cauldron soak test from serve-
+// hostname-over-HTTP: a Target bundles the container/port the soak pods
+// should run and the LoadGenerator used to probe them, so the same harness
+// can exercise kube-proxy and service discovery across several protocols.
+package protocoltarget
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/kubernetes/test/soak/pkg/soak"
+)
+
+// Target describes one protocol the soak harness can drive end to end.
+type Target interface {
+	// Name identifies the target, e.g. for inclusion in logs.
+	Name() string
+	// Container is the container soak pods should run. Targets that probe
+	// the existing serve-hostname deployment rather than a protocol of
+	// their own (DNS, today) reuse that container.
+	Container() corev1.Container
+	// ServicePort is the port the soak Service should expose and route to
+	// Container.
+	ServicePort() corev1.ServicePort
+	// NewProbe returns a LoadGenerator that exercises this target against
+	// the Service's DNS name (serviceHost, no scheme/port) in namespace ns.
+	// loadGeneratorName selects among a target's load generator variants,
+	// where it has more than one (http: get, keepalive, http2, tcp);
+	// targets with only one way to probe them ignore it.
+	NewProbe(serviceHost, ns, loadGeneratorName string) (soak.LoadGenerator, error)
+	// AttributesToPod reports whether Response.Hostname, as returned by this
+	// target's probe, is the name of the serve-hostname pod that served the
+	// request. Only the http target's response body is the pod's own
+	// hostname; grpc/dns/udp responses are a health status, a resolved IP
+	// and an echoed payload respectively, none of which ever match a pod
+	// name, so callers must skip per-pod/per-node attribution for them.
+	AttributesToPod() bool
+}
+
+// New returns the Target selected by name. Valid names are "http", "grpc",
+// "dns" and "udp"; "" defaults to "http". There is no "https" target yet: it
+// would need a TLS-terminating container and certificate that don't exist in
+// this harness, so advertising the flag value without a working target would
+// just fail every TLS handshake.
+func New(name string) (Target, error) {
+	switch name {
+	case "", "http":
+		return httpTarget{}, nil
+	case "grpc":
+		return grpcTarget{}, nil
+	case "dns":
+		return dnsTarget{}, nil
+	case "udp":
+		return udpTarget{}, nil
+	default:
+		return nil, fmt.Errorf("unknown target %q", name)
+	}
+}
@@ -0,0 +1,82 @@
+/*
+Copyright 2015 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package protocoltarget
+
+import (
+	"testing"
+)
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		name           string
+		wantTargetName string
+		wantErr        bool
+	}{
+		{name: "", wantTargetName: "http"},
+		{name: "http", wantTargetName: "http"},
+		{name: "grpc", wantTargetName: "grpc"},
+		{name: "dns", wantTargetName: "dns"},
+		{name: "udp", wantTargetName: "udp"},
+		// https was advertised once but never had a working TLS-terminating
+		// target behind it; New must keep rejecting it rather than silently
+		// handing back a plaintext httpTarget under a misleading name.
+		{name: "https", wantErr: true},
+		{name: "bogus", wantErr: true},
+	}
+	for _, tt := range tests {
+		target, err := New(tt.name)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("New(%q) = %v, nil, want an error", tt.name, target)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("New(%q) = _, %v, want no error", tt.name, err)
+		}
+		if got := target.Name(); got != tt.wantTargetName {
+			t.Errorf("New(%q).Name() = %q, want %q", tt.name, got, tt.wantTargetName)
+		}
+	}
+}
+
+func TestAttributesToPod(t *testing.T) {
+	tests := []struct {
+		target Target
+		want   bool
+	}{
+		{target: httpTarget{}, want: true},
+		{target: grpcTarget{}, want: false},
+		{target: dnsTarget{}, want: false},
+		{target: udpTarget{}, want: false},
+	}
+	for _, tt := range tests {
+		if got := tt.target.AttributesToPod(); got != tt.want {
+			t.Errorf("%s.AttributesToPod() = %v, want %v", tt.target.Name(), got, tt.want)
+		}
+	}
+}
+
+func TestHTTPTargetNewProbe(t *testing.T) {
+	generator, err := httpTarget{}.NewProbe("serve-hostnames.ns", "ns", "")
+	if err != nil {
+		t.Fatalf("NewProbe() = _, %v, want no error", err)
+	}
+	if generator == nil {
+		t.Fatal("NewProbe() returned a nil LoadGenerator")
+	}
+}
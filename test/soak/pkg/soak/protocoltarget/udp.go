@@ -0,0 +1,104 @@
+/*
+Copyright 2015 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package protocoltarget
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/kubernetes/test/soak/pkg/soak"
+)
+
+// udpPort is the port the raw UDP echo server listens on.
+const udpPort = 9376
+
+// udpEchoImage runs a minimal server that echoes back whatever datagram it
+// receives, used to measure kube-proxy's L4 UDP path.
+const udpEchoImage = "registry.k8s.io/e2e-test-images/udp-echo:1.0"
+
+// udpEchoPayload is sent on every probe; its exact content doesn't matter,
+// only that the echo server returns it unchanged.
+var udpEchoPayload = []byte("soak")
+
+const udpReadTimeout = 5 * time.Second
+
+type udpTarget struct{}
+
+func (udpTarget) Name() string {
+	return "udp"
+}
+
+func (udpTarget) Container() corev1.Container {
+	return corev1.Container{
+		Name:  "udp-echo",
+		Image: udpEchoImage,
+		Ports: []corev1.ContainerPort{{ContainerPort: udpPort, Protocol: corev1.ProtocolUDP}},
+	}
+}
+
+func (udpTarget) ServicePort() corev1.ServicePort {
+	return corev1.ServicePort{
+		Protocol:   corev1.ProtocolUDP,
+		Port:       udpPort,
+		TargetPort: intstr.FromInt(udpPort),
+	}
+}
+
+func (udpTarget) AttributesToPod() bool {
+	return false
+}
+
+func (udpTarget) NewProbe(serviceHost, ns, loadGeneratorName string) (soak.LoadGenerator, error) {
+	return &udpEchoGenerator{addr: fmt.Sprintf("%s:%d", serviceHost, udpPort)}, nil
+}
+
+// udpEchoGenerator dials a fresh UDP "connection" (really just a bound
+// socket; UDP is connectionless) for each request, writes the echo payload
+// and waits for it to come back.
+type udpEchoGenerator struct {
+	addr string
+}
+
+func (g *udpEchoGenerator) Name() string {
+	return "udp"
+}
+
+func (g *udpEchoGenerator) Fire(ctx context.Context) (soak.Response, error) {
+	var d net.Dialer
+	conn, err := d.DialContext(ctx, "udp", g.addr)
+	if err != nil {
+		return soak.Response{}, err
+	}
+	defer conn.Close()
+
+	if _, err := conn.Write(udpEchoPayload); err != nil {
+		return soak.Response{}, err
+	}
+	if err := conn.SetReadDeadline(time.Now().Add(udpReadTimeout)); err != nil {
+		return soak.Response{}, err
+	}
+	buf := make([]byte, len(udpEchoPayload))
+	n, err := conn.Read(buf)
+	if err != nil {
+		return soak.Response{}, err
+	}
+	return soak.Response{Hostname: string(buf[:n])}, nil
+}
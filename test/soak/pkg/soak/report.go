@@ -0,0 +1,200 @@
+/*
+Copyright 2015 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package soak
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// percentilesReported are the percentiles included in every summary, in
+// order.
+var percentilesReported = []float64{50, 90, 99, 99.9}
+
+// Report aggregates per-pod and per-node latency histograms across an
+// iteration of the soak test, replacing the old "missing count" only
+// reporting with full latency distributions.
+type Report struct {
+	mu    sync.Mutex
+	pods  map[string]*Histogram
+	nodes map[string]*Histogram
+	all   *Histogram
+}
+
+// NewReport returns an empty Report.
+func NewReport() *Report {
+	return &Report{
+		pods:  make(map[string]*Histogram),
+		nodes: make(map[string]*Histogram),
+		all:   NewHistogram(),
+	}
+}
+
+// PodCount returns the number of responses recorded from podName, or zero if
+// it never responded.
+func (r *Report) PodCount(podName string) int64 {
+	r.mu.Lock()
+	h, ok := r.pods[podName]
+	r.mu.Unlock()
+	if !ok {
+		return 0
+	}
+	return h.Count()
+}
+
+// Overall returns the histogram spanning every observation recorded,
+// regardless of which pod or node served it.
+func (r *Report) Overall() *Histogram {
+	return r.all
+}
+
+// Record adds one observed latency, attributing it to podName and nodeName
+// as well as the overall histogram.
+func (r *Report) Record(podName, nodeName string, d time.Duration) {
+	r.mu.Lock()
+	pod, ok := r.pods[podName]
+	if !ok {
+		pod = NewHistogram()
+		r.pods[podName] = pod
+	}
+	node, ok := r.nodes[nodeName]
+	if !ok {
+		node = NewHistogram()
+		r.nodes[nodeName] = node
+	}
+	r.mu.Unlock()
+
+	pod.Record(d)
+	node.Record(d)
+	r.all.Record(d)
+}
+
+// RecordOverall adds one observed latency to the overall histogram only,
+// without attributing it to any pod or node. Use this for targets whose
+// Response doesn't identify the serving pod (see
+// protocoltarget.Target.AttributesToPod); attributing such a response's
+// opaque Hostname to a pod would just fill the per-pod/per-node histograms
+// with bogus entries.
+func (r *Report) RecordOverall(d time.Duration) {
+	r.all.Record(d)
+}
+
+// summaryRow is one line of a rendered summary: either a pod, a node or the
+// "overall" totals.
+type summaryRow struct {
+	Kind        string             `json:"kind"`
+	Name        string             `json:"name"`
+	Count       int64              `json:"count"`
+	Mean        time.Duration      `json:"meanNanos"`
+	Percentiles map[string]float64 `json:"percentileNanos"`
+}
+
+func (r *Report) rows() []summaryRow {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var rows []summaryRow
+	addRow := func(kind, name string, h *Histogram) {
+		percentiles := make(map[string]float64, len(percentilesReported))
+		for _, p := range percentilesReported {
+			percentiles[percentileLabel(p)] = float64(h.Percentile(p))
+		}
+		rows = append(rows, summaryRow{
+			Kind:        kind,
+			Name:        name,
+			Count:       h.Count(),
+			Mean:        h.Mean(),
+			Percentiles: percentiles,
+		})
+	}
+	for _, name := range sortedKeys(r.pods) {
+		addRow("pod", name, r.pods[name])
+	}
+	for _, name := range sortedKeys(r.nodes) {
+		addRow("node", name, r.nodes[name])
+	}
+	addRow("overall", "all", r.all)
+	return rows
+}
+
+func percentileLabel(p float64) string {
+	return "p" + strings.Replace(fmt.Sprintf("%g", p), ".", "", 1)
+}
+
+func sortedKeys(m map[string]*Histogram) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// WriteSummary renders the report to path. The format is chosen from the
+// file extension: ".json" produces JSON, anything else produces CSV.
+func (r *Report) WriteSummary(path string) error {
+	if strings.HasSuffix(path, ".json") {
+		return r.writeJSON(path)
+	}
+	return r.writeCSV(path)
+}
+
+func (r *Report) writeJSON(path string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	enc := json.NewEncoder(f)
+	enc.SetIndent("", "  ")
+	return enc.Encode(r.rows())
+}
+
+func (r *Report) writeCSV(path string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	w := csv.NewWriter(f)
+	defer w.Flush()
+
+	header := []string{"kind", "name", "count", "mean_ns"}
+	for _, p := range percentilesReported {
+		header = append(header, percentileLabel(p)+"_ns")
+	}
+	if err := w.Write(header); err != nil {
+		return err
+	}
+	for _, row := range r.rows() {
+		record := []string{row.Kind, row.Name, fmt.Sprintf("%d", row.Count), fmt.Sprintf("%d", row.Mean.Nanoseconds())}
+		for _, p := range percentilesReported {
+			record = append(record, fmt.Sprintf("%.0f", row.Percentiles[percentileLabel(p)]))
+		}
+		if err := w.Write(record); err != nil {
+			return err
+		}
+	}
+	return nil
+}
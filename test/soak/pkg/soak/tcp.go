@@ -0,0 +1,54 @@
+/*
+Copyright 2015 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package soak
+
+import (
+	"context"
+	"net"
+	"strings"
+)
+
+// tcpProbeGenerator measures raw TCP connect latency to the target, with no
+// HTTP request/response on top. It is useful for isolating kube-proxy/kernel
+// connection setup time from anything the serve-hostname handler does.
+type tcpProbeGenerator struct {
+	addr string
+}
+
+// NewTCPGenerator returns a LoadGenerator that dials target's host:port and
+// immediately closes the connection, reporting the peer address reached.
+func NewTCPGenerator(target Target) LoadGenerator {
+	addr := string(target)
+	addr = strings.TrimPrefix(addr, "http://")
+	addr = strings.TrimPrefix(addr, "https://")
+	addr = strings.TrimSuffix(addr, "/")
+	return &tcpProbeGenerator{addr: addr}
+}
+
+func (g *tcpProbeGenerator) Name() string {
+	return "tcp"
+}
+
+func (g *tcpProbeGenerator) Fire(ctx context.Context) (Response, error) {
+	var d net.Dialer
+	conn, err := d.DialContext(ctx, "tcp", g.addr)
+	if err != nil {
+		return Response{}, err
+	}
+	defer conn.Close()
+	return Response{Hostname: conn.RemoteAddr().String()}, nil
+}